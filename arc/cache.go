@@ -0,0 +1,404 @@
+// Package arc implements the Adaptive Replacement Cache (ARC) policy: two
+// resident LRU lists (T1 for recency, T2 for frequency) backed by two ghost
+// lists of evicted keys (B1, B2) that drive an adaptive target size for T1.
+package arc
+
+import (
+	"iter"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Eyal-Shalev/powercache"
+	"github.com/Eyal-Shalev/powercache/internal/container/list"
+)
+
+type cacheEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// notice records an entry that left the cache, pending an OnEvict callback
+// once the caller has released the write lock.
+type notice[K comparable, V any] struct {
+	key    K
+	value  V
+	reason powercache.Reason
+}
+
+type Cache[K comparable, V any] struct {
+	t1, t2       *list.List[cacheEntry[K, V]]
+	b1, b2       *list.List[K]
+	t1idx, t2idx map[K]*list.Element[cacheEntry[K, V]]
+	b1idx, b2idx map[K]*list.Element[K]
+	p            int
+	c            int
+	sizer        powercache.Sizer[V]
+	maxBytes     int64
+	size         int64
+	onEvict      func(K, V, powercache.Reason)
+	onExpire     func(K, V)
+
+	hits, misses, evictions, expirations atomic.Uint64
+
+	m *sync.RWMutex
+}
+
+// Len returns the number of resident entries (T1 and T2 combined); ghost
+// entries in B1/B2 don't count.
+func (c *Cache[K, V]) Len() int {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Size returns the running total reported by the cache's [powercache.Sizer],
+// or 0 if none was configured via [WithSizer].
+func (c *Cache[K, V]) Size() int64 {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.size
+}
+
+func (c *Cache[K, V]) sizeOf(value V) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+	return c.sizer(value)
+}
+
+// Get mutates T1/T2 placement on every hit (a T1 hit promotes the entry into
+// T2), so, like [lfu.Cache.Get], it always takes the write lock.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.m.Lock()
+	value, ok := c.unsafeGet(key)
+	c.m.Unlock()
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return value, ok
+}
+
+func (c *Cache[K, V]) unsafeGet(key K) (V, bool) {
+	var zero V
+	if elem, ok := c.t1idx[key]; ok {
+		value := elem.Value.value
+		c.t1ToT2(elem)
+		return value, true
+	}
+	if elem, ok := c.t2idx[key]; ok {
+		c.t2.MoveToFront(elem)
+		return elem.Value.value, true
+	}
+	return zero, false
+}
+
+// t1ToT2 promotes elem, a T1 entry, to the MRU position of T2.
+func (c *Cache[K, V]) t1ToT2(elem *list.Element[cacheEntry[K, V]]) {
+	c.t1.Remove(elem)
+	delete(c.t1idx, elem.Value.key)
+	c.t2idx[elem.Value.key] = c.t2.PushFront(elem.Value)
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.m.Lock()
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) unsafeSet(key K, value V) []notice[K, V] {
+	if elem, ok := c.t1idx[key]; ok {
+		old := elem.Value.value
+		c.size += c.sizeOf(value) - c.sizeOf(old)
+		elem.Value.value = value
+		c.t1ToT2(elem)
+		return []notice[K, V]{{key, old, powercache.ReasonReplace}}
+	}
+	if elem, ok := c.t2idx[key]; ok {
+		old := elem.Value.value
+		c.size += c.sizeOf(value) - c.sizeOf(old)
+		elem.Value.value = value
+		c.t2.MoveToFront(elem)
+		return []notice[K, V]{{key, old, powercache.ReasonReplace}}
+	}
+
+	if elem, ok := c.b1idx[key]; ok {
+		delta := 1
+		if b2n := c.b2.Len(); b2n > c.b1.Len() {
+			delta = b2n / c.b1.Len()
+		}
+		c.p = min(c.p+delta, c.c)
+		notices := c.replace(false)
+		c.b1.Remove(elem)
+		delete(c.b1idx, key)
+		c.insertT2MRU(key, value)
+		return append(notices, c.unsafeEvictToFit()...)
+	}
+
+	if elem, ok := c.b2idx[key]; ok {
+		delta := 1
+		if b1n := c.b1.Len(); b1n > c.b2.Len() {
+			delta = b1n / c.b2.Len()
+		}
+		c.p = max(c.p-delta, 0)
+		notices := c.replace(true)
+		c.b2.Remove(elem)
+		delete(c.b2idx, key)
+		c.insertT2MRU(key, value)
+		return append(notices, c.unsafeEvictToFit()...)
+	}
+
+	var notices []notice[K, V]
+	if c.c > 0 {
+		switch {
+		case c.t1.Len()+c.b1.Len() == c.c:
+			if c.t1.Len() < c.c {
+				c.unsafeRemoveB1LRU()
+				notices = c.replace(false)
+			} else {
+				notices = c.unsafeEvictT1LRU()
+			}
+		case c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= c.c:
+			if c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= 2*c.c {
+				c.unsafeRemoveB2LRU()
+			}
+			notices = c.replace(false)
+		}
+	}
+	c.insertT1MRU(key, value)
+	return append(notices, c.unsafeEvictToFit()...)
+}
+
+// unsafeEvictToFit evicts resident entries, via the usual T1/T2 replace
+// policy, until the byte-size bound is satisfied, when a [Sizer] is
+// configured.
+func (c *Cache[K, V]) unsafeEvictToFit() []notice[K, V] {
+	var notices []notice[K, V]
+	for c.sizer != nil && c.maxBytes > 0 && c.size > c.maxBytes && (c.t1.Len() > 0 || c.t2.Len() > 0) {
+		n := c.replace(false)
+		if len(n) == 0 {
+			// replace leaves T1 alone while it's within p; with T2 empty,
+			// fall back to T1's LRU so the loop always makes progress.
+			n = c.moveT1LRUToB1()
+		}
+		notices = append(notices, n...)
+	}
+	return notices
+}
+
+// replace evicts the LRU of T1 to B1 when T1 has grown past its target p (or
+// sits exactly at p and the triggering key came from B2), otherwise it
+// evicts the LRU of T2 to B2. Either way the resident value is dropped (only
+// the key survives, as a ghost), so it reports a [powercache.ReasonCapacity]
+// notice.
+func (c *Cache[K, V]) replace(inB2 bool) []notice[K, V] {
+	if t1n := c.t1.Len(); t1n > 0 && (t1n > c.p || (t1n == c.p && inB2)) {
+		return c.moveT1LRUToB1()
+	} else if c.t2.Len() > 0 {
+		return c.moveT2LRUToB2()
+	}
+	return nil
+}
+
+func (c *Cache[K, V]) moveT1LRUToB1() []notice[K, V] {
+	lru := c.t1.Back()
+	if lru == nil {
+		return nil
+	}
+	key := lru.Value.key
+	c.t1.Remove(lru)
+	delete(c.t1idx, key)
+	c.size -= c.sizeOf(lru.Value.value)
+	c.b1idx[key] = c.b1.PushFront(key)
+	c.evictions.Add(1)
+	return []notice[K, V]{{key, lru.Value.value, powercache.ReasonCapacity}}
+}
+
+func (c *Cache[K, V]) moveT2LRUToB2() []notice[K, V] {
+	lru := c.t2.Back()
+	if lru == nil {
+		return nil
+	}
+	key := lru.Value.key
+	c.t2.Remove(lru)
+	delete(c.t2idx, key)
+	c.size -= c.sizeOf(lru.Value.value)
+	c.b2idx[key] = c.b2.PushFront(key)
+	c.evictions.Add(1)
+	return []notice[K, V]{{key, lru.Value.value, powercache.ReasonCapacity}}
+}
+
+func (c *Cache[K, V]) unsafeRemoveB1LRU() {
+	lru := c.b1.Back()
+	if lru == nil {
+		return
+	}
+	delete(c.b1idx, lru.Value)
+	c.b1.Remove(lru)
+}
+
+func (c *Cache[K, V]) unsafeRemoveB2LRU() {
+	lru := c.b2.Back()
+	if lru == nil {
+		return
+	}
+	delete(c.b2idx, lru.Value)
+	c.b2.Remove(lru)
+}
+
+// unsafeEvictT1LRU drops the T1 LRU outright, with no ghost entry: the cache
+// is at capacity with no room to spare in B1 either.
+func (c *Cache[K, V]) unsafeEvictT1LRU() []notice[K, V] {
+	lru := c.t1.Back()
+	if lru == nil {
+		return nil
+	}
+	delete(c.t1idx, lru.Value.key)
+	c.t1.Remove(lru)
+	c.size -= c.sizeOf(lru.Value.value)
+	c.evictions.Add(1)
+	return []notice[K, V]{{lru.Value.key, lru.Value.value, powercache.ReasonCapacity}}
+}
+
+func (c *Cache[K, V]) insertT1MRU(key K, value V) {
+	c.t1idx[key] = c.t1.PushFront(cacheEntry[K, V]{key, value})
+	c.size += c.sizeOf(value)
+}
+
+func (c *Cache[K, V]) insertT2MRU(key K, value V) {
+	c.t2idx[key] = c.t2.PushFront(cacheEntry[K, V]{key, value})
+	c.size += c.sizeOf(value)
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.m.Lock()
+	if elem, ok := c.t1idx[key]; ok {
+		c.t1.Remove(elem)
+		delete(c.t1idx, key)
+		c.size -= c.sizeOf(elem.Value.value)
+		c.m.Unlock()
+		c.fire([]notice[K, V]{{key, elem.Value.value, powercache.ReasonDelete}})
+		return
+	}
+	if elem, ok := c.t2idx[key]; ok {
+		c.t2.Remove(elem)
+		delete(c.t2idx, key)
+		c.size -= c.sizeOf(elem.Value.value)
+		c.m.Unlock()
+		c.fire([]notice[K, V]{{key, elem.Value.value, powercache.ReasonDelete}})
+		return
+	}
+	if elem, ok := c.b1idx[key]; ok {
+		c.b1.Remove(elem)
+		delete(c.b1idx, key)
+		c.m.Unlock()
+		return
+	}
+	if elem, ok := c.b2idx[key]; ok {
+		c.b2.Remove(elem)
+		delete(c.b2idx, key)
+	}
+	c.m.Unlock()
+}
+
+// fire runs the configured callbacks for each notice. Callers must invoke it
+// after releasing the write lock, since a callback may itself call back into
+// the cache.
+func (c *Cache[K, V]) fire(notices []notice[K, V]) {
+	for _, n := range notices {
+		if c.onEvict != nil {
+			c.onEvict(n.key, n.value, n.reason)
+		}
+		if n.reason == powercache.ReasonTTL && c.onExpire != nil {
+			c.onExpire(n.key, n.value)
+		}
+	}
+}
+
+func (c *Cache[K, V]) SetFromMap(data map[K]V) {
+	c.SetFromIter(maps.All(data))
+}
+
+func (c *Cache[K, V]) SetFromIter(data iter.Seq2[K, V]) {
+	c.m.Lock()
+	var notices []notice[K, V]
+	for k, v := range data {
+		notices = append(notices, c.unsafeSet(k, v)...)
+	}
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	c.m.Lock()
+
+	if value, ok := c.unsafeGet(key); ok {
+		c.m.Unlock()
+		c.hits.Add(1)
+		return value, nil
+	}
+	c.misses.Add(1)
+
+	value, err := fn()
+	if err != nil {
+		c.m.Unlock()
+		return value, err
+	}
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
+
+	return value, err
+}
+
+func (c *Cache[K, V]) Hits() uint64        { return c.hits.Load() }
+func (c *Cache[K, V]) Misses() uint64      { return c.misses.Load() }
+func (c *Cache[K, V]) Evictions() uint64   { return c.evictions.Load() }
+func (c *Cache[K, V]) Expirations() uint64 { return c.expirations.Load() }
+
+func (c *Cache[K, V]) Reset() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.expirations.Store(0)
+}
+
+func (c *Cache[K, V]) Snapshot() powercache.StatsSnapshot {
+	return powercache.StatsSnapshot{
+		Hits:        c.Hits(),
+		Misses:      c.Misses(),
+		Evictions:   c.Evictions(),
+		Expirations: c.Expirations(),
+		Len:         c.Len(),
+	}
+}
+
+var _ powercache.Cache[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Doable[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.MultiSetter[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Stats = (*Cache[bool, bool])(nil)
+
+// New returns an ARC [Cache] bounded to capacity resident entries. A
+// capacity of 0 means unbounded: T1 grows without limit, and B1/B2 stay
+// empty since nothing is ever evicted to populate them.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		t1:    list.New[cacheEntry[K, V]](),
+		t2:    list.New[cacheEntry[K, V]](),
+		b1:    list.New[K](),
+		b2:    list.New[K](),
+		t1idx: make(map[K]*list.Element[cacheEntry[K, V]]),
+		t2idx: make(map[K]*list.Element[cacheEntry[K, V]]),
+		b1idx: make(map[K]*list.Element[K]),
+		b2idx: make(map[K]*list.Element[K]),
+		c:     capacity,
+		m:     new(sync.RWMutex),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
@@ -0,0 +1,54 @@
+package arc_test
+
+import (
+	"testing"
+
+	"github.com/Eyal-Shalev/powercache/arc"
+)
+
+func TestCache_EvictsAtCapacity(t *testing.T) {
+	c := arc.New[int, string](2)
+	c.Set(1, "a")
+	c.Set(2, "b")
+	c.Set(3, "c")
+
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d; want 2", got)
+	}
+	if got := c.Evictions(); got == 0 {
+		t.Errorf("Evictions() = %d; want > 0", got)
+	}
+}
+
+func TestCache_ZeroCapacityIsUnbounded(t *testing.T) {
+	c := arc.New[int, string](0)
+	const n = 256
+	for i := range n {
+		c.Set(i, "v")
+	}
+
+	if got := c.Len(); got != n {
+		t.Errorf("Len() = %d; want %d (capacity 0 means unbounded)", got, n)
+	}
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions() = %d; want 0", got)
+	}
+	for i := range n {
+		if _, ok := c.Get(i); !ok {
+			t.Errorf("Get(%d): want hit", i)
+		}
+	}
+}
+
+func TestCache_ReplaceDoesNotCountAsEviction(t *testing.T) {
+	c := arc.New[int, string](2)
+	c.Set(1, "a")
+	c.Set(1, "a2")
+
+	if v, ok := c.Get(1); !ok || v != "a2" {
+		t.Errorf("Get(1) = %q, %v; want \"a2\", true", v, ok)
+	}
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions() = %d; want 0 (plain key replacement isn't an eviction)", got)
+	}
+}
@@ -18,3 +18,62 @@ type MultiSetter[K comparable, V any] interface {
 	SetFromMap(data map[K]V)
 	SetFromIter(data iter.Seq2[K, V])
 }
+
+// Sizer measures the weight of a cached value, e.g. its size in bytes.
+// Caches configured with a Sizer bound the running total returned by their
+// Size method, rather than (or in addition to) a plain entry count.
+type Sizer[V any] func(value V) int64
+
+// Reason identifies why an entry left a cache, passed to an OnEvict
+// callback.
+type Reason int
+
+const (
+	// ReasonCapacity means the entry was evicted to make room under a
+	// capacity or byte-size bound.
+	ReasonCapacity Reason = iota
+	// ReasonTTL means the entry was removed because it had expired.
+	ReasonTTL
+	// ReasonDelete means the entry was removed by an explicit Delete call.
+	ReasonDelete
+	// ReasonReplace means the entry's value was replaced by a Set call for
+	// the same key.
+	ReasonReplace
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonTTL:
+		return "ttl"
+	case ReasonDelete:
+		return "delete"
+	case ReasonReplace:
+		return "replace"
+	default:
+		return "unknown"
+	}
+}
+
+// StatsSnapshot is a point-in-time copy of a [Stats] cache's counters.
+type StatsSnapshot struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Len         int
+}
+
+// Stats is implemented by caches that track hit/miss/eviction counters.
+// Implementations update their counters atomically, so Stats methods may be
+// called concurrently with Get/Set/Delete.
+type Stats interface {
+	Hits() uint64
+	Misses() uint64
+	Evictions() uint64
+	Expirations() uint64
+	Len() int
+	Reset()
+	Snapshot() StatsSnapshot
+}
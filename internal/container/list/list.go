@@ -0,0 +1,167 @@
+// Package list implements a doubly linked list, mirroring the standard
+// library's container/list but parameterized over the element type so
+// callers avoid the any/interface{} boxing and type assertions that come
+// with the non-generic version.
+package list
+
+// Element is a node of a [List].
+type Element[V any] struct {
+	next, prev *Element[V]
+	list       *List[V]
+
+	Value V
+}
+
+// Next returns the next list element or nil.
+func (e *Element[V]) Next() *Element[V] {
+	if p := e.next; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// Prev returns the previous list element or nil.
+func (e *Element[V]) Prev() *Element[V] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// List is a doubly linked list. The zero value is an empty list ready to
+// use; [New] returns an initialized one.
+type List[V any] struct {
+	root Element[V]
+	len  int
+}
+
+func (l *List[V]) lazyInit() {
+	if l.root.next == nil {
+		l.init()
+	}
+}
+
+func (l *List[V]) init() *List[V] {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+	return l
+}
+
+// New returns an initialized, empty [List].
+func New[V any]() *List[V] {
+	return new(List[V]).init()
+}
+
+// Len returns the number of elements in the list.
+func (l *List[V]) Len() int {
+	return l.len
+}
+
+// Front returns the first element of the list, or nil if the list is empty.
+func (l *List[V]) Front() *Element[V] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element of the list, or nil if the list is empty.
+func (l *List[V]) Back() *Element[V] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+func (l *List[V]) insert(e, at *Element[V]) *Element[V] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+func (l *List[V]) insertValue(v V, at *Element[V]) *Element[V] {
+	return l.insert(&Element[V]{Value: v}, at)
+}
+
+func (l *List[V]) remove(e *Element[V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil
+	e.prev = nil
+	e.list = nil
+	l.len--
+}
+
+func (l *List[V]) move(e, at *Element[V]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// Remove removes e from l if e is an element of l and returns e's value.
+func (l *List[V]) Remove(e *Element[V]) V {
+	if e.list == l {
+		l.remove(e)
+	}
+	return e.Value
+}
+
+// PushFront inserts a new element with value v at the front of the list and
+// returns it.
+func (l *List[V]) PushFront(v V) *Element[V] {
+	l.lazyInit()
+	return l.insertValue(v, &l.root)
+}
+
+// PushBack inserts a new element with value v at the back of the list and
+// returns it.
+func (l *List[V]) PushBack(v V) *Element[V] {
+	l.lazyInit()
+	return l.insertValue(v, l.root.prev)
+}
+
+// InsertBefore inserts a new element with value v immediately before mark and
+// returns it. Mark must be an element of l.
+func (l *List[V]) InsertBefore(v V, mark *Element[V]) *Element[V] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insertValue(v, mark.prev)
+}
+
+// InsertAfter inserts a new element with value v immediately after mark and
+// returns it. Mark must be an element of l.
+func (l *List[V]) InsertAfter(v V, mark *Element[V]) *Element[V] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insertValue(v, mark)
+}
+
+// MoveToFront moves e to the front of l. e must be an element of l.
+func (l *List[V]) MoveToFront(e *Element[V]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// MoveToBack moves e to the back of l. e must be an element of l.
+func (l *List[V]) MoveToBack(e *Element[V]) {
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
@@ -0,0 +1,361 @@
+// Package lfu implements Ketan Shah's O(1) LFU eviction policy: a
+// doubly-linked list of frequency nodes, each owning a doubly-linked list of
+// the entries currently at that frequency.
+package lfu
+
+import (
+	"iter"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Eyal-Shalev/powercache"
+	"github.com/Eyal-Shalev/powercache/internal/container/list"
+)
+
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt time.Time
+	freqNode *list.Element[*freqNode[K, V]]
+	node     *list.Element[*entry[K, V]]
+}
+
+type freqNode[K comparable, V any] struct {
+	freq    int
+	entries *list.List[*entry[K, V]]
+}
+
+// notice records an entry that left the cache, pending an OnEvict/OnExpire
+// callback once the caller has released the write lock.
+type notice[K comparable, V any] struct {
+	key    K
+	value  V
+	reason powercache.Reason
+}
+
+type Cache[K comparable, V any] struct {
+	index    map[K]*entry[K, V]
+	freqs    *list.List[*freqNode[K, V]]
+	capacity int
+	ttl      time.Duration
+	sizer    powercache.Sizer[V]
+	maxBytes int64
+	size     int64
+	onEvict  func(K, V, powercache.Reason)
+	onExpire func(K, V)
+
+	hits, misses, evictions, expirations atomic.Uint64
+
+	m *sync.RWMutex
+}
+
+// Len returns the number of entries currently cached.
+func (c *Cache[K, V]) Len() int {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return len(c.index)
+}
+
+// Size returns the running total reported by the cache's [powercache.Sizer],
+// or 0 if none was configured via [WithSizer].
+func (c *Cache[K, V]) Size() int64 {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.size
+}
+
+func (c *Cache[K, V]) sizeOf(value V) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+	return c.sizer(value)
+}
+
+// Get looks up key, bumping its frequency by one on a hit. Like
+// [lru.Cache.Get] and unlike [ttl.Cache.Get], this always takes the write lock: a
+// frequency bump mutates the freq-node list on every access, so there's no
+// cheaper read-only path.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.m.Lock()
+	value, ok, notices := c.unsafeGet(key)
+	c.m.Unlock()
+	c.fire(notices)
+
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return value, ok
+}
+
+func (c *Cache[K, V]) unsafeGet(key K) (V, bool, []notice[K, V]) {
+	var zero V
+	e, ok := c.index[key]
+	if !ok {
+		return zero, false, nil
+	}
+	if c.ttl > 0 && time.Now().After(e.expireAt) {
+		c.expirations.Add(1)
+		return zero, false, []notice[K, V]{c.unsafeRemoveEntry(e, powercache.ReasonTTL)}
+	}
+	c.bump(e)
+	return e.value, true, nil
+}
+
+// bump moves e from its current freq node to the freq+1 node, creating that
+// node immediately after the current one if it doesn't already exist, and
+// removes the current freq node if it's left empty.
+func (c *Cache[K, V]) bump(e *entry[K, V]) {
+	curElem := e.freqNode
+	cur := curElem.Value
+	cur.entries.Remove(e.node)
+
+	nextElem := curElem.Next()
+	var next *freqNode[K, V]
+	if nextElem != nil && nextElem.Value.freq == cur.freq+1 {
+		next = nextElem.Value
+	} else {
+		next = &freqNode[K, V]{freq: cur.freq + 1, entries: list.New[*entry[K, V]]()}
+		nextElem = c.freqs.InsertAfter(next, curElem)
+	}
+	e.node = next.entries.PushBack(e)
+	e.freqNode = nextElem
+
+	if cur.entries.Len() == 0 {
+		c.freqs.Remove(curElem)
+	}
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.m.Lock()
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) unsafeSet(key K, value V) []notice[K, V] {
+	if e, ok := c.index[key]; ok {
+		old := e.value
+		c.size += c.sizeOf(value) - c.sizeOf(old)
+		e.value = value
+		if c.ttl > 0 {
+			e.expireAt = time.Now().Add(c.ttl)
+		}
+		c.bump(e)
+		return []notice[K, V]{{key, old, powercache.ReasonReplace}}
+	}
+
+	var notices []notice[K, V]
+	if c.capacity > 0 && len(c.index) >= c.capacity {
+		if n, ok := c.unsafeEvict(); ok {
+			notices = append(notices, n)
+		}
+	}
+
+	c.unsafeInsert(key, value)
+	return append(notices, c.unsafeEvictToFit()...)
+}
+
+// unsafeEvictToFit evicts entries, by the same policy as [Cache.unsafeEvict],
+// until the byte-size bound is satisfied, when a [Sizer] is configured.
+func (c *Cache[K, V]) unsafeEvictToFit() []notice[K, V] {
+	var notices []notice[K, V]
+	for c.sizer != nil && c.maxBytes > 0 && c.size > c.maxBytes && len(c.index) > 0 {
+		n, ok := c.unsafeEvict()
+		if !ok {
+			break
+		}
+		notices = append(notices, n)
+	}
+	return notices
+}
+
+// unsafeInsert adds a new entry at frequency 1, creating that freq node at
+// the front of the freq list if it doesn't already exist there.
+func (c *Cache[K, V]) unsafeInsert(key K, value V) {
+	front := c.freqs.Front()
+	var freq1 *freqNode[K, V]
+	var freq1Elem *list.Element[*freqNode[K, V]]
+	if front != nil && front.Value.freq == 1 {
+		freq1, freq1Elem = front.Value, front
+	} else {
+		freq1 = &freqNode[K, V]{freq: 1, entries: list.New[*entry[K, V]]()}
+		freq1Elem = c.freqs.PushFront(freq1)
+	}
+
+	e := &entry[K, V]{key: key, value: value, freqNode: freq1Elem}
+	if c.ttl > 0 {
+		e.expireAt = time.Now().Add(c.ttl)
+	}
+	e.node = freq1.entries.PushBack(e)
+	c.index[key] = e
+	c.size += c.sizeOf(value)
+}
+
+// unsafeEvict prefers an already-expired entry (cheapest to lose); absent
+// one, it evicts the oldest entry at the lowest frequency, i.e. the front
+// entry of the front freq node. The second return value is false if there
+// was nothing to evict.
+func (c *Cache[K, V]) unsafeEvict() (notice[K, V], bool) {
+	if c.ttl > 0 {
+		if e := c.unsafeFindExpired(); e != nil {
+			c.expirations.Add(1)
+			return c.unsafeRemoveEntry(e, powercache.ReasonTTL), true
+		}
+	}
+
+	freqElem := c.freqs.Front()
+	if freqElem == nil {
+		return notice[K, V]{}, false
+	}
+	victim := freqElem.Value.entries.Front()
+	if victim == nil {
+		return notice[K, V]{}, false
+	}
+	c.evictions.Add(1)
+	return c.unsafeRemoveEntry(victim.Value, powercache.ReasonCapacity), true
+}
+
+func (c *Cache[K, V]) unsafeFindExpired() *entry[K, V] {
+	now := time.Now()
+	for freqElem := c.freqs.Front(); freqElem != nil; freqElem = freqElem.Next() {
+		for node := freqElem.Value.entries.Front(); node != nil; node = node.Next() {
+			if now.After(node.Value.expireAt) {
+				return node.Value
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cache[K, V]) unsafeRemoveEntry(e *entry[K, V], reason powercache.Reason) notice[K, V] {
+	freqElem := e.freqNode
+	freq := freqElem.Value
+	freq.entries.Remove(e.node)
+	if freq.entries.Len() == 0 {
+		c.freqs.Remove(freqElem)
+	}
+	delete(c.index, e.key)
+	c.size -= c.sizeOf(e.value)
+	return notice[K, V]{e.key, e.value, reason}
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.m.Lock()
+	e, ok := c.index[key]
+	if !ok {
+		c.m.Unlock()
+		return
+	}
+	n := c.unsafeRemoveEntry(e, powercache.ReasonDelete)
+	c.m.Unlock()
+	c.fire([]notice[K, V]{n})
+}
+
+// fire runs the configured callbacks for each notice. Callers must invoke it
+// after releasing the write lock, since a callback may itself call back into
+// the cache.
+func (c *Cache[K, V]) fire(notices []notice[K, V]) {
+	for _, n := range notices {
+		if c.onEvict != nil {
+			c.onEvict(n.key, n.value, n.reason)
+		}
+		if n.reason == powercache.ReasonTTL && c.onExpire != nil {
+			c.onExpire(n.key, n.value)
+		}
+	}
+}
+
+func (c *Cache[K, V]) SetFromMap(data map[K]V) {
+	c.SetFromIter(maps.All(data))
+}
+
+func (c *Cache[K, V]) SetFromIter(data iter.Seq2[K, V]) {
+	c.m.Lock()
+	var notices []notice[K, V]
+	for k, v := range data {
+		notices = append(notices, c.unsafeSet(k, v)...)
+	}
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	c.m.Lock()
+
+	value, ok, getNotices := c.unsafeGet(key)
+	if ok {
+		c.m.Unlock()
+		c.fire(getNotices)
+		c.hits.Add(1)
+		return value, nil
+	}
+	c.misses.Add(1)
+
+	value, err := fn()
+	if err != nil {
+		c.m.Unlock()
+		c.fire(getNotices)
+		return value, err
+	}
+	setNotices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(getNotices)
+	c.fire(setNotices)
+
+	return value, nil
+}
+
+func (c *Cache[K, V]) Hits() uint64        { return c.hits.Load() }
+func (c *Cache[K, V]) Misses() uint64      { return c.misses.Load() }
+func (c *Cache[K, V]) Evictions() uint64   { return c.evictions.Load() }
+func (c *Cache[K, V]) Expirations() uint64 { return c.expirations.Load() }
+
+func (c *Cache[K, V]) Reset() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.expirations.Store(0)
+}
+
+func (c *Cache[K, V]) Snapshot() powercache.StatsSnapshot {
+	return powercache.StatsSnapshot{
+		Hits:        c.Hits(),
+		Misses:      c.Misses(),
+		Evictions:   c.Evictions(),
+		Expirations: c.Expirations(),
+		Len:         c.Len(),
+	}
+}
+
+var _ powercache.Cache[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Doable[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.MultiSetter[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Stats = (*Cache[bool, bool])(nil)
+
+// New returns an LFU [Cache] bounded to capacity entries, with no TTL.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		index:    make(map[K]*entry[K, V]),
+		freqs:    list.New[*freqNode[K, V]](),
+		capacity: capacity,
+		m:        new(sync.RWMutex),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewWithTTL returns an LFU [Cache] bounded to capacity entries, where each
+// entry expires ttl after it was last set (a Get hit bumps its frequency but
+// does not extend its expiry), and expired entries are the preferred eviction
+// victims.
+func NewWithTTL[K comparable, V any](capacity int, ttl time.Duration, opts ...Option[K, V]) *Cache[K, V] {
+	c := New[K, V](capacity, opts...)
+	c.ttl = ttl
+	return c
+}
@@ -0,0 +1,61 @@
+package lfu_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Eyal-Shalev/powercache/lfu"
+)
+
+func TestCache_EvictsLeastFrequentlyUsed(t *testing.T) {
+	c := lfu.New[int, string](2)
+	c.Set(1, "a")
+	c.Set(2, "b")
+
+	// Bump 1's frequency above 2's.
+	if _, ok := c.Get(1); !ok {
+		t.Fatalf("Get(1): want hit")
+	}
+
+	// At capacity, 2 is the least frequently used and should be evicted.
+	c.Set(3, "c")
+
+	if _, ok := c.Get(1); !ok {
+		t.Errorf("Get(1): want hit, it was used more recently")
+	}
+	if _, ok := c.Get(2); ok {
+		t.Errorf("Get(2): want miss, it should have been evicted")
+	}
+	if _, ok := c.Get(3); !ok {
+		t.Errorf("Get(3): want hit")
+	}
+	if got := c.Evictions(); got != 1 {
+		t.Errorf("Evictions() = %d; want 1", got)
+	}
+}
+
+func TestCache_ReplaceDoesNotCountAsEviction(t *testing.T) {
+	c := lfu.New[int, string](2)
+	c.Set(1, "a")
+	c.Set(1, "a2")
+
+	if v, ok := c.Get(1); !ok || v != "a2" {
+		t.Errorf("Get(1) = %q, %v; want \"a2\", true", v, ok)
+	}
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions() = %d; want 0 (plain key replacement isn't an eviction)", got)
+	}
+}
+
+func TestCache_TTLExpiry(t *testing.T) {
+	c := lfu.NewWithTTL[int, string](2, time.Millisecond)
+	c.Set(1, "a")
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := c.Get(1); ok {
+		t.Errorf("Get(1): want miss, entry should have expired")
+	}
+	if got := c.Expirations(); got != 1 {
+		t.Errorf("Expirations() = %d; want 1", got)
+	}
+}
@@ -0,0 +1,34 @@
+package lfu
+
+import "github.com/Eyal-Shalev/powercache"
+
+// Option configures a [Cache] at construction time.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithSizer bounds the cache's total reported [Cache.Size] to maxBytes, as
+// measured by sizer, evicting by the usual LFU policy whenever a Set would
+// push the running total over budget. A maxBytes of 0 means unbounded.
+func WithSizer[K comparable, V any](sizer powercache.Sizer[V], maxBytes int64) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.sizer = sizer
+		c.maxBytes = maxBytes
+	}
+}
+
+// WithOnEvict registers a callback invoked whenever an entry leaves the
+// cache, with the reason it left. It runs outside the cache's write lock, so
+// it may safely call back into the same cache.
+func WithOnEvict[K comparable, V any](fn func(key K, value V, reason powercache.Reason)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// WithOnExpire registers a callback invoked whenever an entry is removed
+// specifically because its TTL elapsed. It fires in addition to, not
+// instead of, an OnEvict callback configured via [WithOnEvict].
+func WithOnExpire[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onExpire = fn
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"iter"
 	"maps"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Eyal-Shalev/powercache"
@@ -11,70 +12,241 @@ import (
 )
 
 type cacheEntry[K comparable, V any] struct {
-	key   K
-	value V
+	key      K
+	value    V
+	expireAt time.Time
+}
+
+// notice records an entry that left the cache, pending an OnEvict/OnExpire
+// callback once the caller has released the write lock.
+type notice[K comparable, V any] struct {
+	key    K
+	value  V
+	reason powercache.Reason
 }
 
 type Cache[K comparable, V any] struct {
-	entries *list.List[cacheEntry[K, V]]
-	m       *sync.RWMutex
+	entries         *list.List[cacheEntry[K, V]]
+	index           map[K]*list.Element[cacheEntry[K, V]]
+	maxEntries      int
+	ttl             time.Duration
+	sizer           powercache.Sizer[V]
+	maxBytes        int64
+	size            int64
+	onEvict         func(K, V, powercache.Reason)
+	onExpire        func(K, V)
+	janitorInterval time.Duration
+
+	hits, misses, evictions, expirations atomic.Uint64
+
+	m         *sync.RWMutex
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-func (c *Cache[K, V]) Get(key K) (V, bool) {
+// Len returns the number of entries currently cached, including any that
+// have expired but not yet been evicted.
+func (c *Cache[K, V]) Len() int {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.entries.Len()
+}
+
+// Size returns the running total reported by the cache's [powercache.Sizer],
+// or 0 if none was configured via [WithSizer].
+func (c *Cache[K, V]) Size() int64 {
 	c.m.RLock()
 	defer c.m.RUnlock()
-	return c.unsafeGet(key)
+	return c.size
+}
+
+func (c *Cache[K, V]) sizeOf(value V) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+	return c.sizer(value)
 }
 
-func (c *Cache[K, V]) unsafeGet(key K) (V, bool) {
+// Get looks up key, promoting it to the front of the LRU list on a hit.
+// Unlike [ttl.Cache.Get], this always takes the write lock: promotion
+// mutates the list on every access, so there's no cheaper read-only path.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.m.Lock()
+	value, found, expired := c.unsafeLookup(key)
+	var notices []notice[K, V]
+	if expired {
+		notices = c.unsafeExpireIfStale(key)
+	}
+	c.m.Unlock()
+	c.fire(notices)
+
+	if found && !expired {
+		c.hits.Add(1)
+		return value, true
+	}
+	c.misses.Add(1)
+
 	var zero V
-	root := c.entries.Front()
-	if root == nil {
-		return zero, false
+	return zero, false
+}
+
+// unsafeLookup reports whether key is present and, if so, whether it has
+// expired. On a non-expired hit it also promotes the entry to the front of
+// the LRU list.
+func (c *Cache[K, V]) unsafeLookup(key K) (value V, found, expired bool) {
+	entry, ok := c.index[key]
+	if !ok {
+		return value, false, false
 	}
-	entry := c.findEntry(key)
-	if entry == nil {
-		return zero, false
+	if c.ttl > 0 && time.Now().After(entry.Value.expireAt) {
+		return value, true, true
 	}
 	c.entries.MoveToFront(entry)
-	return entry.Value.value, true
+	return entry.Value.value, true, false
 }
 
-func (c *Cache[K, V]) findEntry(key K) *list.Element[cacheEntry[K, V]] {
-	root := c.entries.Front()
-	if root == nil {
+// unsafeExpireIfStale re-checks key under the write lock and removes it if
+// it has expired.
+func (c *Cache[K, V]) unsafeExpireIfStale(key K) []notice[K, V] {
+	entry, ok := c.index[key]
+	if !ok || c.ttl <= 0 || !time.Now().After(entry.Value.expireAt) {
 		return nil
 	}
-
-	for cur := root; cur != nil; cur = cur.Next() {
-		if cur.Value.key == key {
-			return cur
-		}
-	}
-	return nil
+	c.entries.Remove(entry)
+	delete(c.index, key)
+	c.size -= c.sizeOf(entry.Value.value)
+	c.expirations.Add(1)
+	return []notice[K, V]{{key, entry.Value.value, powercache.ReasonTTL}}
 }
 
 func (c *Cache[K, V]) Set(key K, value V) {
 	c.m.Lock()
-	defer c.m.Unlock()
-	c.unsafeSet(key, value)
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
 }
 
-func (c *Cache[K, V]) unsafeSet(key K, value V) {
-	if entry := c.findEntry(key); entry != nil {
+func (c *Cache[K, V]) unsafeSet(key K, value V) []notice[K, V] {
+	var expireAt time.Time
+	if c.ttl > 0 {
+		expireAt = time.Now().Add(c.ttl)
+	}
+
+	if entry, ok := c.index[key]; ok {
+		old := entry.Value.value
+		c.size += c.sizeOf(value) - c.sizeOf(old)
 		entry.Value.value = value
+		entry.Value.expireAt = expireAt
 		c.entries.MoveToFront(entry)
-	} else {
-		c.entries.InsertBefore(cacheEntry[K, V]{key, value}, c.entries.Front())
+		return []notice[K, V]{{key, old, powercache.ReasonReplace}}
+	}
+
+	entry := c.entries.PushFront(cacheEntry[K, V]{key: key, value: value, expireAt: expireAt})
+	c.index[key] = entry
+	c.size += c.sizeOf(value)
+
+	return c.unsafeEvictToFit()
+}
+
+// unsafeEvictToFit evicts the oldest entries until both the entry-count
+// bound and the byte-size bound (when a [Sizer] is configured) are
+// satisfied.
+func (c *Cache[K, V]) unsafeEvictToFit() []notice[K, V] {
+	var notices []notice[K, V]
+	for c.maxEntries > 0 && c.entries.Len() > c.maxEntries {
+		notices = append(notices, c.unsafeEvictOldest())
 	}
+	for c.sizer != nil && c.maxBytes > 0 && c.size > c.maxBytes && c.entries.Len() > 0 {
+		notices = append(notices, c.unsafeEvictOldest())
+	}
+	return notices
+}
+
+func (c *Cache[K, V]) unsafeEvictOldest() notice[K, V] {
+	oldest := c.entries.Back()
+	c.entries.Remove(oldest)
+	delete(c.index, oldest.Value.key)
+	c.size -= c.sizeOf(oldest.Value.value)
+	c.evictions.Add(1)
+	return notice[K, V]{oldest.Value.key, oldest.Value.value, powercache.ReasonCapacity}
 }
 
 func (c *Cache[K, V]) Delete(key K) {
 	c.m.Lock()
-	defer c.m.Unlock()
-	if entry := c.findEntry(key); entry != nil {
-		c.entries.Remove(entry)
+	entry, ok := c.index[key]
+	if !ok {
+		c.m.Unlock()
+		return
+	}
+	c.entries.Remove(entry)
+	delete(c.index, key)
+	c.size -= c.sizeOf(entry.Value.value)
+	c.m.Unlock()
+	c.fire([]notice[K, V]{{key, entry.Value.value, powercache.ReasonDelete}})
+}
+
+// fire runs the configured callbacks for each notice. Callers must invoke it
+// after releasing the write lock, since a callback may itself call back into
+// the cache.
+func (c *Cache[K, V]) fire(notices []notice[K, V]) {
+	for _, n := range notices {
+		if c.onEvict != nil {
+			c.onEvict(n.key, n.value, n.reason)
+		}
+		if n.reason == powercache.ReasonTTL && c.onExpire != nil {
+			c.onExpire(n.key, n.value)
+		}
+	}
+}
+
+// runJanitor periodically sweeps expired entries until Close is called.
+// Started by New when [WithJanitor] configures an interval.
+func (c *Cache[K, V]) runJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.sweepExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// sweepExpired removes every currently-expired entry, so they don't sit
+// pinned at the tail of the LRU list until a Get happens to touch them.
+func (c *Cache[K, V]) sweepExpired() {
+	if c.ttl <= 0 {
+		return
+	}
+	c.m.Lock()
+	now := time.Now()
+	var notices []notice[K, V]
+	for back := c.entries.Back(); back != nil; {
+		prev := back.Prev()
+		if !now.After(back.Value.expireAt) {
+			back = prev
+			continue
+		}
+		c.entries.Remove(back)
+		delete(c.index, back.Value.key)
+		c.size -= c.sizeOf(back.Value.value)
+		c.expirations.Add(1)
+		notices = append(notices, notice[K, V]{back.Value.key, back.Value.value, powercache.ReasonTTL})
+		back = prev
 	}
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+// Close stops the background janitor started by [WithJanitor], if any. It is
+// safe to call multiple times, and safe to call even if no janitor was
+// configured.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
 }
 
 func (c *Cache[K, V]) SetFromMap(data map[K]V) {
@@ -83,43 +255,90 @@ func (c *Cache[K, V]) SetFromMap(data map[K]V) {
 
 func (c *Cache[K, V]) SetFromIter(data iter.Seq2[K, V]) {
 	c.m.Lock()
-	defer c.m.Unlock()
+	var notices []notice[K, V]
 	for k, v := range data {
-		c.unsafeSet(k, v)
+		notices = append(notices, c.unsafeSet(k, v)...)
 	}
+	c.m.Unlock()
+	c.fire(notices)
 }
 
 func (c *Cache[K, V]) Do(key K, fn func() (V, error)) (V, error) {
-	// Try the faster [Cache.Get] which uses [RWMutex.RLock]
-	if value, ok := c.Get(key); ok {
-		return value, nil
-	}
-
-	// Lock for write
+	// unsafeLookup promotes on a hit, so unlike ttl/sieve there's no cheaper
+	// RLock-based path to try first: go straight to the write lock.
 	c.m.Lock()
-	defer c.m.Unlock()
 
-	// Check if between [Cache.Get] and [Cache.m.Lock] the data was added.
-	if value, ok := c.unsafeGet(key); ok {
+	value, found, expired := c.unsafeLookup(key)
+	if found && !expired {
+		c.m.Unlock()
+		c.hits.Add(1)
 		return value, nil
 	}
+	c.misses.Add(1)
+
+	var notices []notice[K, V]
+	if expired {
+		notices = c.unsafeExpireIfStale(key)
+	}
 
 	value, err := fn()
 	if err != nil {
+		c.m.Unlock()
+		c.fire(notices)
 		return value, err
 	}
-	c.unsafeSet(key, value)
+	notices = append(notices, c.unsafeSet(key, value)...)
+	c.m.Unlock()
+	c.fire(notices)
 
 	return value, err
 }
 
+func (c *Cache[K, V]) Hits() uint64        { return c.hits.Load() }
+func (c *Cache[K, V]) Misses() uint64      { return c.misses.Load() }
+func (c *Cache[K, V]) Evictions() uint64   { return c.evictions.Load() }
+func (c *Cache[K, V]) Expirations() uint64 { return c.expirations.Load() }
+
+func (c *Cache[K, V]) Reset() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.expirations.Store(0)
+}
+
+func (c *Cache[K, V]) Snapshot() powercache.StatsSnapshot {
+	return powercache.StatsSnapshot{
+		Hits:        c.Hits(),
+		Misses:      c.Misses(),
+		Evictions:   c.Evictions(),
+		Expirations: c.Expirations(),
+		Len:         c.Len(),
+	}
+}
+
 var _ powercache.Cache[bool, bool] = (*Cache[bool, bool])(nil)
 var _ powercache.Doable[bool, bool] = (*Cache[bool, bool])(nil)
 var _ powercache.MultiSetter[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Stats = (*Cache[bool, bool])(nil)
 
-func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
-	return &Cache[K, V]{
-		entries: list.New[cacheEntry[K, V]](),
-		m:       new(sync.RWMutex),
+// New returns an LRU [Cache] bounded to maxEntries. Once the cache holds
+// maxEntries items, each Set of a new key evicts the least recently used
+// entry. A maxEntries of 0 means unbounded. A ttl of 0 means entries never
+// expire.
+func New[K comparable, V any](ttl time.Duration, maxEntries int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		entries:    list.New[cacheEntry[K, V]](),
+		index:      make(map[K]*list.Element[cacheEntry[K, V]]),
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		m:          new(sync.RWMutex),
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.janitorInterval > 0 {
+		go c.runJanitor(c.janitorInterval)
 	}
+	return c
 }
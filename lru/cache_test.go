@@ -0,0 +1,157 @@
+package lru_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eyal-Shalev/powercache"
+	"github.com/Eyal-Shalev/powercache/lru"
+)
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := lru.New[int, string](0, 2)
+	c.Set(1, "a")
+	c.Set(2, "b")
+
+	// Touch 1 so 2 becomes the least recently used.
+	if _, ok := c.Get(1); !ok {
+		t.Fatalf("Get(1): want hit")
+	}
+
+	c.Set(3, "c")
+
+	if _, ok := c.Get(1); !ok {
+		t.Errorf("Get(1): want hit, it was used more recently")
+	}
+	if _, ok := c.Get(2); ok {
+		t.Errorf("Get(2): want miss, it should have been evicted")
+	}
+	if _, ok := c.Get(3); !ok {
+		t.Errorf("Get(3): want hit")
+	}
+	if got := c.Evictions(); got != 1 {
+		t.Errorf("Evictions() = %d; want 1", got)
+	}
+}
+
+func TestCache_ReplaceDoesNotCountAsEviction(t *testing.T) {
+	c := lru.New[int, string](0, 2)
+	c.Set(1, "a")
+	c.Set(1, "a2")
+
+	if v, ok := c.Get(1); !ok || v != "a2" {
+		t.Errorf("Get(1) = %q, %v; want \"a2\", true", v, ok)
+	}
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions() = %d; want 0 (plain key replacement isn't an eviction)", got)
+	}
+}
+
+func TestCache_TTLExpiry(t *testing.T) {
+	c := lru.New[int, string](time.Millisecond, 0)
+	c.Set(1, "a")
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := c.Get(1); ok {
+		t.Errorf("Get(1): want miss, entry should have expired")
+	}
+	if got := c.Expirations(); got != 1 {
+		t.Errorf("Expirations() = %d; want 1", got)
+	}
+}
+
+func TestCache_SizerEvictsOverByteBudget(t *testing.T) {
+	sizer := func(v string) int64 { return int64(len(v)) }
+	c := lru.New[int, string](0, 0, lru.WithSizer[int, string](sizer, 2))
+	c.Set(1, "a")
+	c.Set(2, "b")
+
+	// Budget is 2 bytes; this third byte pushes it over and evicts 1, the
+	// least recently used.
+	c.Set(3, "c")
+
+	if _, ok := c.Get(1); ok {
+		t.Errorf("Get(1): want miss, it should have been evicted to stay within the byte budget")
+	}
+	if got := c.Size(); got > 2 {
+		t.Errorf("Size() = %d; want <= 2", got)
+	}
+	if got := c.Evictions(); got != 1 {
+		t.Errorf("Evictions() = %d; want 1", got)
+	}
+}
+
+func TestCache_CallbacksAndStatsSnapshot(t *testing.T) {
+	type event struct {
+		key    int
+		value  string
+		reason powercache.Reason
+	}
+	var evicted []event
+	var expired []struct {
+		key   int
+		value string
+	}
+
+	c := lru.New[int, string](time.Millisecond, 1,
+		lru.WithOnEvict[int, string](func(key int, value string, reason powercache.Reason) {
+			evicted = append(evicted, event{key, value, reason})
+		}),
+		lru.WithOnExpire[int, string](func(key int, value string) {
+			expired = append(expired, struct {
+				key   int
+				value string
+			}{key, value})
+		}),
+	)
+
+	c.Set(1, "a")
+	c.Set(2, "b") // capacity 1: evicts 1 with ReasonCapacity
+
+	if len(evicted) != 1 || evicted[0].key != 1 || evicted[0].reason != powercache.ReasonCapacity {
+		t.Errorf("evicted = %+v; want one ReasonCapacity callback for key 1", evicted)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if _, ok := c.Get(2); ok {
+		t.Errorf("Get(2): want miss, entry should have expired")
+	}
+
+	if len(evicted) != 2 || evicted[1].key != 2 || evicted[1].reason != powercache.ReasonTTL {
+		t.Errorf("evicted = %+v; want a second, ReasonTTL callback for key 2", evicted)
+	}
+	if len(expired) != 1 || expired[0].key != 2 {
+		t.Errorf("expired = %+v; want one OnExpire callback for key 2", expired)
+	}
+
+	snap := c.Snapshot()
+	if snap.Misses != 1 || snap.Evictions != 1 || snap.Expirations != 1 || snap.Len != 0 {
+		t.Errorf("Snapshot() = %+v; want {Misses:1 Evictions:1 Expirations:1 Len:0, ...}", snap)
+	}
+
+	c.Reset()
+	if snap := c.Snapshot(); snap.Misses != 0 || snap.Evictions != 0 || snap.Expirations != 0 {
+		t.Errorf("Snapshot() after Reset() = %+v; want all counters zeroed", snap)
+	}
+}
+
+func TestCache_ConcurrentGetSet(t *testing.T) {
+	c := lru.New[int, int](0, 16)
+	for i := range 16 {
+		c.Set(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			for range 1000 {
+				c.Set(key, key)
+				c.Get(key)
+			}
+		}(i % 16)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,131 @@
+// Package shard wraps N independent [powercache.Cache] instances behind a
+// single key-hash router, so that lock contention on a single cache's mutex
+// is spread across N locks instead.
+package shard
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"maps"
+	"sync"
+
+	"github.com/Eyal-Shalev/powercache"
+)
+
+// Hasher computes the shard-routing hash for a key of type K. Configure one
+// via [WithHasher] for key types [Cache] doesn't already route well by
+// default.
+type Hasher[K comparable] func(key K) uint64
+
+var seed = maphash.MakeSeed()
+
+// defaultHash fast-paths the common case of string keys through maphash's
+// dedicated helper, and falls back to hashing the default %v formatting of
+// any other comparable key. That fallback is slower than a type-specific
+// hash, so callers with a performance-sensitive non-string key type should
+// supply their own [Hasher] via [WithHasher].
+func defaultHash[K comparable](key K) uint64 {
+	if s, ok := any(key).(string); ok {
+		return maphash.String(seed, s)
+	}
+	return maphash.String(seed, fmt.Sprintf("%v", key))
+}
+
+type Cache[K comparable, V any] struct {
+	shards []powercache.Cache[K, V]
+	hasher Hasher[K]
+}
+
+// New returns a [Cache] that routes each key to one of n shards, each
+// produced by factory. A [powercache.Cache] returned by factory that also
+// implements [powercache.Doable] or [powercache.MultiSetter] lets the
+// wrapper support the matching method; see [Cache.Do] and
+// [Cache.SetFromMap]/[Cache.SetFromIter].
+func New[K comparable, V any](n int, factory func() powercache.Cache[K, V], opts ...Option[K, V]) *Cache[K, V] {
+	if n <= 0 {
+		panic("shard: n must be positive")
+	}
+	c := &Cache[K, V]{
+		shards: make([]powercache.Cache[K, V], n),
+		hasher: defaultHash[K],
+	}
+	for i := range c.shards {
+		c.shards[i] = factory()
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Cache[K, V]) shardFor(key K) powercache.Cache[K, V] {
+	return c.shards[c.hasher(key)%uint64(len(c.shards))]
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	return c.shardFor(key).Get(key)
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.shardFor(key).Set(key, value)
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.shardFor(key).Delete(key)
+}
+
+// Do delegates to the shard holding key, so the single-flight guarantee of
+// the underlying [powercache.Doable] still holds per key (other keys routed
+// to other shards proceed concurrently). It panics if that shard's cache
+// doesn't implement [powercache.Doable].
+func (c *Cache[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	shard := c.shardFor(key)
+	do, ok := shard.(powercache.Doable[K, V])
+	if !ok {
+		panic(fmt.Sprintf("shard: %T does not implement powercache.Doable", shard))
+	}
+	return do.Do(key, fn)
+}
+
+// SetFromMap fans data out across shards and sets each shard's share
+// concurrently. It panics if a shard that receives any of data doesn't
+// implement [powercache.MultiSetter].
+func (c *Cache[K, V]) SetFromMap(data map[K]V) {
+	c.SetFromIter(maps.All(data))
+}
+
+// SetFromIter fans data out across shards and sets each shard's share
+// concurrently. It panics if a shard that receives any of data doesn't
+// implement [powercache.MultiSetter].
+func (c *Cache[K, V]) SetFromIter(data iter.Seq2[K, V]) {
+	perShard := make([]map[K]V, len(c.shards))
+	for k, v := range data {
+		i := c.hasher(k) % uint64(len(c.shards))
+		if perShard[i] == nil {
+			perShard[i] = make(map[K]V)
+		}
+		perShard[i][k] = v
+	}
+
+	var wg sync.WaitGroup
+	for i, m := range perShard {
+		if len(m) == 0 {
+			continue
+		}
+		setter, ok := c.shards[i].(powercache.MultiSetter[K, V])
+		if !ok {
+			panic(fmt.Sprintf("shard: %T does not implement powercache.MultiSetter", c.shards[i]))
+		}
+		wg.Add(1)
+		go func(setter powercache.MultiSetter[K, V], m map[K]V) {
+			defer wg.Done()
+			setter.SetFromMap(m)
+		}(setter, m)
+	}
+	wg.Wait()
+}
+
+var _ powercache.Cache[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Doable[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.MultiSetter[bool, bool] = (*Cache[bool, bool])(nil)
@@ -0,0 +1,13 @@
+package shard
+
+// Option configures a [Cache] at construction time.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithHasher overrides the default key-routing hash. Use it for key types
+// [Cache]'s default hasher doesn't handle well, or to pin routing behavior
+// across processes.
+func WithHasher[K comparable, V any](hasher Hasher[K]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.hasher = hasher
+	}
+}
@@ -0,0 +1,45 @@
+package shard_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Eyal-Shalev/powercache"
+	"github.com/Eyal-Shalev/powercache/lru"
+	"github.com/Eyal-Shalev/powercache/shard"
+)
+
+// BenchmarkCache_ConcurrentSetGet compares a single plain [lru.Cache] against
+// a [shard.Cache] of the same total capacity, each hammered by b.RunParallel
+// goroutines (one per GOMAXPROCS) alternately setting and getting keys cycled
+// from the same pool of 4096. This is the access pattern that makes a single RWMutex the bottleneck
+// the shard package exists to relieve.
+func BenchmarkCache_ConcurrentSetGet(b *testing.B) {
+	const shards = 16
+	const perShardCapacity = 1024
+
+	b.Run("plain", func(b *testing.B) {
+		c := lru.New[string, int](0, shards*perShardCapacity)
+		benchmarkConcurrentSetGet(b, c)
+	})
+
+	b.Run("sharded", func(b *testing.B) {
+		c := shard.New[string, int](shards, func() powercache.Cache[string, int] {
+			return lru.New[string, int](0, perShardCapacity)
+		})
+		benchmarkConcurrentSetGet(b, c)
+	})
+}
+
+func benchmarkConcurrentSetGet(b *testing.B, c powercache.Cache[string, int]) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := strconv.Itoa(i % 4096)
+			c.Set(key, i)
+			c.Get(key)
+			i++
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package shard_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eyal-Shalev/powercache"
+	"github.com/Eyal-Shalev/powercache/lru"
+	"github.com/Eyal-Shalev/powercache/shard"
+)
+
+func newShardedLRU() *shard.Cache[string, int] {
+	return shard.New[string, int](4, func() powercache.Cache[string, int] {
+		return lru.New[string, int](0, 16)
+	})
+}
+
+func TestCache_SetGetDelete(t *testing.T) {
+	c := newShardedLRU()
+
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		c.Set(key, i)
+	}
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		if v, ok := c.Get(key); !ok || v != i {
+			t.Errorf("Get(%q) = %d, %v; want %d, true", key, v, ok, i)
+		}
+	}
+
+	c.Delete("c")
+	if _, ok := c.Get("c"); ok {
+		t.Errorf("Get(%q): want miss after Delete", "c")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Get(%q): want hit, unrelated key shouldn't be affected by Delete", "a")
+	}
+}
+
+func TestCache_Do(t *testing.T) {
+	c := newShardedLRU()
+
+	calls := 0
+	fn := func() (int, error) {
+		calls++
+		return 42, nil
+	}
+
+	if v, err := c.Do("a", fn); err != nil || v != 42 {
+		t.Fatalf("Do(%q) = %d, %v; want 42, nil", "a", v, err)
+	}
+	if v, err := c.Do("a", fn); err != nil || v != 42 {
+		t.Fatalf("Do(%q) = %d, %v; want 42, nil", "a", v, err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times; want 1, the second Do should have hit the cache", calls)
+	}
+
+	wantErr := errors.New("boom")
+	if _, err := c.Do("b", func() (int, error) { return 0, wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("Do(%q) error = %v; want %v", "b", err, wantErr)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Get(%q): want miss, a failed Do shouldn't populate the cache", "b")
+	}
+}
+
+func TestCache_SetFromMap(t *testing.T) {
+	c := newShardedLRU()
+
+	data := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+	c.SetFromMap(data)
+
+	for key, want := range data {
+		if v, ok := c.Get(key); !ok || v != want {
+			t.Errorf("Get(%q) = %d, %v; want %d, true", key, v, ok, want)
+		}
+	}
+}
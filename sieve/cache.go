@@ -0,0 +1,276 @@
+// Package sieve implements the SIEVE eviction policy, a simple
+// scan-resistant alternative to LRU that tracks a single "visited" bit per
+// entry instead of reordering a list on every access.
+package sieve
+
+import (
+	"iter"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Eyal-Shalev/powercache"
+	"github.com/Eyal-Shalev/powercache/internal/container/list"
+)
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	visited atomic.Bool
+}
+
+// notice records an entry that left the cache, pending an OnEvict callback
+// once the caller has released the write lock.
+type notice[K comparable, V any] struct {
+	key    K
+	value  V
+	reason powercache.Reason
+}
+
+type Cache[K comparable, V any] struct {
+	entries  *list.List[cacheEntry[K, V]]
+	index    map[K]*list.Element[cacheEntry[K, V]]
+	hand     *list.Element[cacheEntry[K, V]]
+	capacity int
+	sizer    powercache.Sizer[V]
+	maxBytes int64
+	size     int64
+	onEvict  func(K, V, powercache.Reason)
+	onExpire func(K, V)
+
+	hits, misses, evictions, expirations atomic.Uint64
+
+	m *sync.RWMutex
+}
+
+// Len returns the number of entries currently cached.
+func (c *Cache[K, V]) Len() int {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.entries.Len()
+}
+
+// Size returns the running total reported by the cache's [powercache.Sizer],
+// or 0 if none was configured via [WithSizer].
+func (c *Cache[K, V]) Size() int64 {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.size
+}
+
+func (c *Cache[K, V]) sizeOf(value V) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+	return c.sizer(value)
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.m.RLock()
+	value, ok := c.unsafeGet(key)
+	c.m.RUnlock()
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return value, ok
+}
+
+func (c *Cache[K, V]) unsafeGet(key K) (V, bool) {
+	var zero V
+	entry, ok := c.index[key]
+	if !ok {
+		return zero, false
+	}
+	entry.Value.visited.Store(true)
+	return entry.Value.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.m.Lock()
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) unsafeSet(key K, value V) []notice[K, V] {
+	if entry, ok := c.index[key]; ok {
+		old := entry.Value.value
+		c.size += c.sizeOf(value) - c.sizeOf(old)
+		entry.Value.value = value
+		entry.Value.visited.Store(true)
+		return []notice[K, V]{{key, old, powercache.ReasonReplace}}
+	}
+
+	var notices []notice[K, V]
+	if c.capacity > 0 && c.entries.Len() >= c.capacity {
+		if n, ok := c.unsafeEvict(); ok {
+			notices = append(notices, n)
+		}
+	}
+
+	entry := c.entries.PushFront(cacheEntry[K, V]{key: key, value: value})
+	c.index[key] = entry
+	c.size += c.sizeOf(value)
+
+	for c.sizer != nil && c.maxBytes > 0 && c.size > c.maxBytes && c.entries.Len() > 0 {
+		n, ok := c.unsafeEvict()
+		if !ok {
+			break
+		}
+		notices = append(notices, n)
+	}
+	return notices
+}
+
+// unsafeEvict advances the hand from where the previous eviction left off
+// (or the tail, on the first eviction), clearing visited bits until it finds
+// an unvisited node, wrapping to the tail whenever it runs off the head. The
+// second return value is false if there was nothing to evict.
+func (c *Cache[K, V]) unsafeEvict() (notice[K, V], bool) {
+	hand := c.hand
+	if hand == nil {
+		hand = c.entries.Back()
+	}
+
+	for hand != nil && hand.Value.visited.CompareAndSwap(true, false) {
+		if prev := hand.Prev(); prev != nil {
+			hand = prev
+		} else {
+			hand = c.entries.Back()
+		}
+	}
+	if hand == nil {
+		return notice[K, V]{}, false
+	}
+
+	c.hand = hand.Prev()
+	delete(c.index, hand.Value.key)
+	c.entries.Remove(hand)
+	c.size -= c.sizeOf(hand.Value.value)
+	c.evictions.Add(1)
+	return notice[K, V]{hand.Value.key, hand.Value.value, powercache.ReasonCapacity}, true
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.m.Lock()
+	entry, ok := c.index[key]
+	if !ok {
+		c.m.Unlock()
+		return
+	}
+	if c.hand == entry {
+		c.hand = entry.Prev()
+	}
+	delete(c.index, key)
+	c.entries.Remove(entry)
+	c.size -= c.sizeOf(entry.Value.value)
+	c.m.Unlock()
+	c.fire([]notice[K, V]{{key, entry.Value.value, powercache.ReasonDelete}})
+}
+
+// fire runs the configured callbacks for each notice. Callers must invoke it
+// after releasing the write lock, since a callback may itself call back into
+// the cache.
+func (c *Cache[K, V]) fire(notices []notice[K, V]) {
+	for _, n := range notices {
+		if c.onEvict != nil {
+			c.onEvict(n.key, n.value, n.reason)
+		}
+		if n.reason == powercache.ReasonTTL && c.onExpire != nil {
+			c.onExpire(n.key, n.value)
+		}
+	}
+}
+
+func (c *Cache[K, V]) SetFromMap(data map[K]V) {
+	c.SetFromIter(maps.All(data))
+}
+
+func (c *Cache[K, V]) SetFromIter(data iter.Seq2[K, V]) {
+	c.m.Lock()
+	var notices []notice[K, V]
+	for k, v := range data {
+		notices = append(notices, c.unsafeSet(k, v)...)
+	}
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	// Try the faster RLock-based lookup first, without yet counting a hit or
+	// a miss: the write-locked re-check below is the authoritative one, so
+	// that a single Do call never counts both.
+	c.m.RLock()
+	value, ok := c.unsafeGet(key)
+	c.m.RUnlock()
+	if ok {
+		c.hits.Add(1)
+		return value, nil
+	}
+
+	// Lock for write
+	c.m.Lock()
+
+	// Check if between the RLock check above and c.m.Lock the data was
+	// added.
+	if value, ok := c.unsafeGet(key); ok {
+		c.m.Unlock()
+		c.hits.Add(1)
+		return value, nil
+	}
+	c.misses.Add(1)
+
+	value, err := fn()
+	if err != nil {
+		c.m.Unlock()
+		return value, err
+	}
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
+
+	return value, err
+}
+
+func (c *Cache[K, V]) Hits() uint64        { return c.hits.Load() }
+func (c *Cache[K, V]) Misses() uint64      { return c.misses.Load() }
+func (c *Cache[K, V]) Evictions() uint64   { return c.evictions.Load() }
+func (c *Cache[K, V]) Expirations() uint64 { return c.expirations.Load() }
+
+func (c *Cache[K, V]) Reset() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.expirations.Store(0)
+}
+
+func (c *Cache[K, V]) Snapshot() powercache.StatsSnapshot {
+	return powercache.StatsSnapshot{
+		Hits:        c.Hits(),
+		Misses:      c.Misses(),
+		Evictions:   c.Evictions(),
+		Expirations: c.Expirations(),
+		Len:         c.Len(),
+	}
+}
+
+var _ powercache.Cache[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Doable[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.MultiSetter[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Stats = (*Cache[bool, bool])(nil)
+
+// New returns a SIEVE [Cache] bounded to capacity entries.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		entries:  list.New[cacheEntry[K, V]](),
+		index:    make(map[K]*list.Element[cacheEntry[K, V]]),
+		capacity: capacity,
+		m:        new(sync.RWMutex),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
@@ -0,0 +1,73 @@
+package sieve_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Eyal-Shalev/powercache/sieve"
+)
+
+func TestCache_VisitedSurvivesEviction(t *testing.T) {
+	c := sieve.New[int, string](2)
+	c.Set(1, "a")
+	c.Set(2, "b")
+
+	// Mark 1 visited; 2 is never touched.
+	if _, ok := c.Get(1); !ok {
+		t.Fatalf("Get(1): want hit")
+	}
+
+	// Capacity is exceeded: SIEVE should clear 1's visited bit and spare it,
+	// evicting the untouched 2 instead, even though 2 is more recent.
+	c.Set(3, "c")
+
+	if v, ok := c.Get(1); !ok || v != "a" {
+		t.Errorf("Get(1) = %q, %v; want \"a\", true", v, ok)
+	}
+	if _, ok := c.Get(2); ok {
+		t.Errorf("Get(2): want miss, key should have been evicted")
+	}
+	if v, ok := c.Get(3); !ok || v != "c" {
+		t.Errorf("Get(3) = %q, %v; want \"c\", true", v, ok)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d; want 2", got)
+	}
+	if got := c.Evictions(); got != 1 {
+		t.Errorf("Evictions() = %d; want 1", got)
+	}
+}
+
+func TestCache_ReplaceDoesNotCountAsEviction(t *testing.T) {
+	c := sieve.New[int, string](2)
+	c.Set(1, "a")
+	c.Set(1, "a2")
+	c.Set(1, "a3")
+
+	if v, ok := c.Get(1); !ok || v != "a3" {
+		t.Errorf("Get(1) = %q, %v; want \"a3\", true", v, ok)
+	}
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions() = %d; want 0 (plain key replacement isn't an eviction)", got)
+	}
+}
+
+func TestCache_ConcurrentGetSet(t *testing.T) {
+	c := sieve.New[int, int](16)
+	for i := range 16 {
+		c.Set(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			for range 1000 {
+				c.Set(key, key)
+				c.Get(key)
+			}
+		}(i % 16)
+	}
+	wg.Wait()
+}
@@ -4,6 +4,7 @@ import (
 	"iter"
 	"maps"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Eyal-Shalev/powercache"
@@ -14,68 +15,163 @@ type cacheEntry[V any] struct {
 	expireAt time.Time
 }
 
+// notice records an entry that left the cache, pending an OnEvict/OnExpire
+// callback once the caller has released the write lock.
+type notice[K comparable, V any] struct {
+	key    K
+	value  V
+	reason powercache.Reason
+}
+
 type Cache[K comparable, V any] struct {
-	data map[K]cacheEntry[V]
-	ttl  time.Duration
-	m    *sync.RWMutex
+	data            map[K]cacheEntry[V]
+	ttl             time.Duration
+	onEvict         func(K, V, powercache.Reason)
+	onExpire        func(K, V)
+	janitorInterval time.Duration
+
+	hits, misses, evictions, expirations atomic.Uint64
+
+	m         *sync.RWMutex
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 func (c *Cache[K, V]) Get(key K) (V, bool) {
 	c.m.RLock()
-	defer c.m.RUnlock()
-	return c.unsafeGet(key)
-}
+	value, found, expired := c.unsafeLookup(key)
+	c.m.RUnlock()
+
+	if found && !expired {
+		c.hits.Add(1)
+		return value, true
+	}
+	c.misses.Add(1)
+
+	if expired {
+		c.m.Lock()
+		notices := c.unsafeExpireIfStale(key)
+		c.m.Unlock()
+		c.fire(notices)
+	}
 
-func (c *Cache[K, V]) unsafeGet(key K) (V, bool) {
 	var zero V
+	return zero, false
+}
+
+// unsafeLookup reports whether key is present and, if so, whether it has
+// expired; it never mutates the cache.
+func (c *Cache[K, V]) unsafeLookup(key K) (value V, found, expired bool) {
 	entry, ok := c.data[key]
 	if !ok {
-		return zero, false
+		return value, false, false
 	}
 	if time.Now().After(entry.expireAt) {
-		return zero, false
+		return value, true, true
 	}
-	return entry.value, true
+	return entry.value, true, false
+}
+
+// unsafeExpireIfStale re-checks key under the write lock (it may have been
+// refreshed or removed since unsafeLookup last saw it) and removes it if
+// still expired.
+func (c *Cache[K, V]) unsafeExpireIfStale(key K) []notice[K, V] {
+	entry, ok := c.data[key]
+	if !ok || !time.Now().After(entry.expireAt) {
+		return nil
+	}
+	delete(c.data, key)
+	c.expirations.Add(1)
+	return []notice[K, V]{{key, entry.value, powercache.ReasonTTL}}
 }
 
 func (c *Cache[K, V]) Set(key K, value V) {
 	c.m.Lock()
-	defer c.m.Unlock()
-	c.unsafeSet(key, value)
+	notices := c.unsafeSet(key, value)
+	c.m.Unlock()
+	c.fire(notices)
 }
 
-func (c *Cache[K, V]) unsafeSet(key K, value V) {
+func (c *Cache[K, V]) unsafeSet(key K, value V) []notice[K, V] {
+	var notices []notice[K, V]
+	if old, ok := c.data[key]; ok {
+		notices = append(notices, notice[K, V]{key, old.value, powercache.ReasonReplace})
+	}
 	c.data[key] = cacheEntry[V]{value, time.Now().Add(c.ttl)}
+	return notices
 }
 
 func (c *Cache[K, V]) Delete(key K) {
 	c.m.Lock()
-	defer c.m.Unlock()
+	notices := c.unsafeDelete(key)
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+func (c *Cache[K, V]) unsafeDelete(key K) []notice[K, V] {
+	entry, ok := c.data[key]
+	if !ok {
+		return nil
+	}
 	delete(c.data, key)
+	return []notice[K, V]{{key, entry.value, powercache.ReasonDelete}}
+}
+
+// fire runs the configured callbacks for each notice. Callers must invoke it
+// after releasing the write lock, since a callback may itself call back into
+// the cache.
+func (c *Cache[K, V]) fire(notices []notice[K, V]) {
+	for _, n := range notices {
+		if c.onEvict != nil {
+			c.onEvict(n.key, n.value, n.reason)
+		}
+		if n.reason == powercache.ReasonTTL && c.onExpire != nil {
+			c.onExpire(n.key, n.value)
+		}
+	}
 }
 
 func (c *Cache[K, V]) Do(key K, fn func() (V, error)) (V, error) {
-	// Try the faster [Cache.Get] which uses [RWMutex.RLock]
-	if value, ok := c.Get(key); ok {
+	// Try the faster RLock-based lookup first, without yet counting a hit or
+	// a miss: the write-locked re-check below is the authoritative one, so
+	// that a single Do call never counts both.
+	c.m.RLock()
+	value, found, expired := c.unsafeLookup(key)
+	c.m.RUnlock()
+	if found && !expired {
+		c.hits.Add(1)
 		return value, nil
 	}
 
 	// Lock for write
 	c.m.Lock()
-	defer c.m.Unlock()
 
-	// Check if between [Cache.Get] and [Cache.m.Lock] the data was added.
-	if value, ok := c.unsafeGet(key); ok {
+	// Check if between the RLock check above and c.m.Lock the data was
+	// added.
+	value, found, expired = c.unsafeLookup(key)
+	if found && !expired {
+		c.m.Unlock()
+		c.hits.Add(1)
 		return value, nil
 	}
+	c.misses.Add(1)
+
+	var notices []notice[K, V]
+	if expired {
+		notices = c.unsafeExpireIfStale(key)
+	}
 
 	value, err := fn()
 	if err != nil {
+		c.m.Unlock()
+		c.fire(notices)
 		return value, err
 	}
-	c.unsafeSet(key, value)
+	notices = append(notices, c.unsafeSet(key, value)...)
+	c.m.Unlock()
+	c.fire(notices)
 
-	return value, err
+	return value, nil
 }
 
 func (c *Cache[K, V]) SetFromMap(data map[K]V) {
@@ -84,20 +180,102 @@ func (c *Cache[K, V]) SetFromMap(data map[K]V) {
 
 func (c *Cache[K, V]) SetFromIter(data iter.Seq2[K, V]) {
 	c.m.Lock()
-	defer c.m.Unlock()
+	var notices []notice[K, V]
 	for key, value := range data {
-		c.unsafeSet(key, value)
+		notices = append(notices, c.unsafeSet(key, value)...)
+	}
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+// Len returns the number of entries currently cached, including any that
+// have expired but not yet been evicted.
+func (c *Cache[K, V]) Len() int {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return len(c.data)
+}
+
+// runJanitor periodically sweeps expired entries until Close is called.
+// Started by New when [WithJanitor] configures an interval.
+func (c *Cache[K, V]) runJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.sweepExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// sweepExpired removes every currently-expired entry, so they don't sit
+// pinned in the cache until a Get happens to touch them.
+func (c *Cache[K, V]) sweepExpired() {
+	c.m.Lock()
+	now := time.Now()
+	var notices []notice[K, V]
+	for key, entry := range c.data {
+		if now.After(entry.expireAt) {
+			delete(c.data, key)
+			c.expirations.Add(1)
+			notices = append(notices, notice[K, V]{key, entry.value, powercache.ReasonTTL})
+		}
+	}
+	c.m.Unlock()
+	c.fire(notices)
+}
+
+// Close stops the background janitor started by [WithJanitor], if any. It is
+// safe to call multiple times, and safe to call even if no janitor was
+// configured.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+func (c *Cache[K, V]) Hits() uint64        { return c.hits.Load() }
+func (c *Cache[K, V]) Misses() uint64      { return c.misses.Load() }
+func (c *Cache[K, V]) Evictions() uint64   { return c.evictions.Load() }
+func (c *Cache[K, V]) Expirations() uint64 { return c.expirations.Load() }
+
+func (c *Cache[K, V]) Reset() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.expirations.Store(0)
+}
+
+func (c *Cache[K, V]) Snapshot() powercache.StatsSnapshot {
+	return powercache.StatsSnapshot{
+		Hits:        c.Hits(),
+		Misses:      c.Misses(),
+		Evictions:   c.Evictions(),
+		Expirations: c.Expirations(),
+		Len:         c.Len(),
 	}
 }
 
 var _ powercache.Cache[bool, bool] = (*Cache[bool, bool])(nil)
 var _ powercache.Doable[bool, bool] = (*Cache[bool, bool])(nil)
 var _ powercache.MultiSetter[bool, bool] = (*Cache[bool, bool])(nil)
+var _ powercache.Stats = (*Cache[bool, bool])(nil)
 
-func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
-	return &Cache[K, V]{
+func New[K comparable, V any](ttl time.Duration, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
 		data: make(map[K]cacheEntry[V]),
 		m:    new(sync.RWMutex),
 		ttl:  ttl,
+		done: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.janitorInterval > 0 {
+		go c.runJanitor(c.janitorInterval)
 	}
+	return c
 }
@@ -0,0 +1,89 @@
+package ttl_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eyal-Shalev/powercache/ttl"
+)
+
+func TestCache_GetSetDelete(t *testing.T) {
+	c := ttl.New[int, string](time.Hour)
+	c.Set(1, "a")
+
+	if v, ok := c.Get(1); !ok || v != "a" {
+		t.Errorf("Get(1) = %q, %v; want \"a\", true", v, ok)
+	}
+
+	c.Delete(1)
+	if _, ok := c.Get(1); ok {
+		t.Errorf("Get(1): want miss after Delete")
+	}
+	if got := c.Misses(); got != 1 {
+		t.Errorf("Misses() = %d; want 1", got)
+	}
+}
+
+func TestCache_ReplaceDoesNotCountAsEviction(t *testing.T) {
+	c := ttl.New[int, string](time.Hour)
+	c.Set(1, "a")
+	c.Set(1, "a2")
+
+	if v, ok := c.Get(1); !ok || v != "a2" {
+		t.Errorf("Get(1) = %q, %v; want \"a2\", true", v, ok)
+	}
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions() = %d; want 0 (plain key replacement isn't an eviction)", got)
+	}
+}
+
+func TestCache_TTLExpiry(t *testing.T) {
+	c := ttl.New[int, string](time.Millisecond)
+	c.Set(1, "a")
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := c.Get(1); ok {
+		t.Errorf("Get(1): want miss, entry should have expired")
+	}
+	if got := c.Expirations(); got != 1 {
+		t.Errorf("Expirations() = %d; want 1", got)
+	}
+}
+
+func TestCache_JanitorSweepsExpiredEntriesAndCloseStops(t *testing.T) {
+	c := ttl.New[int, string](time.Millisecond, ttl.WithJanitor[int, string](time.Millisecond))
+	defer c.Close()
+	c.Set(1, "a")
+
+	deadline := time.Now().Add(time.Second)
+	for c.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len() = %d; want 0, janitor should have swept the expired entry", got)
+	}
+
+	c.Close()
+	c.Close() // safe to call more than once
+}
+
+func TestCache_ConcurrentGetSet(t *testing.T) {
+	c := ttl.New[int, int](time.Hour)
+	for i := range 16 {
+		c.Set(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			for range 1000 {
+				c.Set(key, key)
+				c.Get(key)
+			}
+		}(i % 16)
+	}
+	wg.Wait()
+}